@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hclparse
+
+import (
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// jsonSuffixes lists the filename suffixes that ParseFileAuto treats as
+// JSON by default. Order does not matter: isJSONFilename matches against
+// every entry, so a filename that satisfies more than one suffix (such as
+// ".tf.json", which also satisfies ".json") is still detected correctly
+// no matter which entry is tested first.
+var jsonSuffixes = []string{
+	".tf.json",
+	".json",
+}
+
+// ParseFileAuto reads the given filename and parses it with whichever of
+// ParseHCLFile or ParseJSONFile is appropriate for it, chosen by
+// inspecting the filename's suffix: ".json" and ".tf.json" (along with
+// any suffix registered with RegisterJSONSuffix) are parsed as JSON, and
+// every other filename is parsed as HCL native syntax.
+//
+// This saves callers -- such as Terraform's configuration loader -- from
+// needing to reimplement this same dispatch between the json and
+// hclsyntax packages themselves.
+func (p *Parser) ParseFileAuto(filename string) (*hcl.File, hcl.Diagnostics) {
+	if isJSONFilename(filename) {
+		return p.ParseJSONFile(filename)
+	}
+	return p.ParseHCLFile(filename)
+}
+
+// RegisterJSONSuffix extends the set of filename suffixes that
+// ParseFileAuto recognizes as JSON, beyond the built-in ".json" and
+// ".tf.json". This is useful for callers that layer their own compound
+// suffix on top of JSON, such as ".json.tmpl". A suffix that already ends
+// in ".json", such as ".hcl.json", is recognized without registering it.
+//
+// Registering a suffix that is already registered is a no-op.
+// RegisterJSONSuffix affects every Parser for the remaining lifetime of
+// the program, so it is intended to be called during program
+// initialization, before ParseFileAuto is in concurrent use; it does not
+// synchronize access to the registered suffix list itself.
+func RegisterJSONSuffix(suffix string) {
+	for _, existing := range jsonSuffixes {
+		if existing == suffix {
+			return
+		}
+	}
+	jsonSuffixes = append(jsonSuffixes, suffix)
+}
+
+func isJSONFilename(filename string) bool {
+	for _, suffix := range jsonSuffixes {
+		if strings.HasSuffix(filename, suffix) {
+			return true
+		}
+	}
+	return false
+}
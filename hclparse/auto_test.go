@@ -0,0 +1,97 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package hclparse
+
+import (
+	"os"
+	"testing"
+)
+
+func TestIsJSONFilename(t *testing.T) {
+	tests := []struct {
+		filename string
+		want     bool
+	}{
+		{"config.tf.json", true},
+		{"config.json", true},
+		{"config.tf", false},
+		{"config.hcl", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filename, func(t *testing.T) {
+			if got := isJSONFilename(test.filename); got != test.want {
+				t.Errorf("isJSONFilename(%q) = %v, want %v", test.filename, got, test.want)
+			}
+		})
+	}
+}
+
+func TestRegisterJSONSuffix(t *testing.T) {
+	const suffix = ".json.tmpl"
+	const filename = "config.json.tmpl"
+
+	if isJSONFilename(filename) {
+		t.Fatalf("%q already detected as JSON before registering %q", filename, suffix)
+	}
+
+	before := len(jsonSuffixes)
+
+	RegisterJSONSuffix(suffix)
+	if !isJSONFilename(filename) {
+		t.Fatalf("%q not detected as JSON after registering %q", filename, suffix)
+	}
+	if got, want := len(jsonSuffixes), before+1; got != want {
+		t.Fatalf("wrong jsonSuffixes length after registering: got %d, want %d", got, want)
+	}
+
+	// Registering the same suffix again must be a no-op.
+	RegisterJSONSuffix(suffix)
+	if got, want := len(jsonSuffixes), before+1; got != want {
+		t.Fatalf("registering a duplicate suffix grew jsonSuffixes: got %d, want %d", got, want)
+	}
+}
+
+func TestParserParseFileAutoDispatch(t *testing.T) {
+	// ParseFileAuto dispatches purely on the filename, so writing the
+	// same invalid content under a JSON name and an HCL name is enough
+	// to prove which of ParseJSONFile and ParseHCLFile handled it: the
+	// JSON and HCL scanners disagree about what constitutes a body, so
+	// parsing that content through each path produces different
+	// diagnostics.
+	const src = `not valid as either dialect {`
+
+	dir := t.TempDir()
+	jsonPath := dir + "/config.tf.json"
+	hclPath := dir + "/config.tf"
+	writeFile(t, jsonPath, src)
+	writeFile(t, hclPath, src)
+
+	p := NewParser()
+
+	_, jsonDiags := p.ParseFileAuto(jsonPath)
+	_, jsonDirectDiags := p.ParseJSONFile(jsonPath)
+	if len(jsonDiags) == 0 || len(jsonDirectDiags) == 0 {
+		t.Fatalf("expected diagnostics from invalid input")
+	}
+	if jsonDiags[0].Summary != jsonDirectDiags[0].Summary {
+		t.Errorf("ParseFileAuto(%q) did not dispatch to ParseJSONFile: got %q, want %q", jsonPath, jsonDiags[0].Summary, jsonDirectDiags[0].Summary)
+	}
+
+	_, hclDiags := p.ParseFileAuto(hclPath)
+	_, hclDirectDiags := p.ParseHCLFile(hclPath)
+	if len(hclDiags) == 0 || len(hclDirectDiags) == 0 {
+		t.Fatalf("expected diagnostics from invalid input")
+	}
+	if hclDiags[0].Summary != hclDirectDiags[0].Summary {
+		t.Errorf("ParseFileAuto(%q) did not dispatch to ParseHCLFile: got %q, want %q", hclPath, hclDiags[0].Summary, hclDirectDiags[0].Summary)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write fixture %q: %s", path, err)
+	}
+}
@@ -0,0 +1,114 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"sync"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Parser is a very light wrapper around the package-level Parse function
+// that retains a cache of the files it has parsed so far, keyed by the
+// filename given to ParseFileFS or ParseReader.
+//
+// The cache is intended to allow a caller to recover the bytes and name of
+// a file that's already been parsed, for example so that hcl.Diagnostics
+// can be rendered with source code snippets. It does not deduplicate
+// parsing work; calling ParseFileFS or ParseReader twice for the same
+// filename will parse twice and overwrite the earlier cache entry.
+//
+// The zero value of Parser is not valid to use; construct instances with
+// NewParser instead.
+type Parser struct {
+	mu    sync.Mutex
+	files map[string]*hcl.File
+}
+
+// NewParser creates a new, empty Parser.
+func NewParser() *Parser {
+	return &Parser{
+		files: map[string]*hcl.File{},
+	}
+}
+
+// ParseFileFS reads the given filename from fsys and parses it as JSON,
+// in the same manner as ParseFile. This allows HCL-in-JSON configuration
+// to be loaded from any implementation of io/fs.FS, such as embed.FS,
+// fstest.MapFS, or a virtual filesystem provided by a third-party module.
+func (p *Parser) ParseFileFS(fsys fs.FS, filename string) (*hcl.File, hcl.Diagnostics) {
+	f, err := fsys.Open(filename)
+	if err != nil {
+		return nil, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to open file",
+				Detail:   fmt.Sprintf("The file %q could not be opened.", filename),
+			},
+		}
+	}
+	defer f.Close()
+
+	return p.ParseReader(f, filename)
+}
+
+// ParseReader reads all of r and parses the result as JSON, in the same
+// manner as Parse. The given filename is used only to tag the returned
+// file and any diagnostics produced while reading or parsing it; r is
+// assumed to already be positioned at the start of the content to parse.
+func (p *Parser) ParseReader(r io.Reader, filename string) (*hcl.File, hcl.Diagnostics) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read file",
+				Detail:   fmt.Sprintf("The file %q was opened, but an error occured while reading it.", filename),
+			},
+		}
+	}
+
+	file, diags := Parse(src, filename)
+	p.mu.Lock()
+	p.files[filename] = file
+	p.mu.Unlock()
+	return file, diags
+}
+
+// Files returns a snapshot of the files that have been parsed so far by
+// this Parser, keyed by the filenames passed to ParseFileFS or
+// ParseReader.
+func (p *Parser) Files() map[string]*hcl.File {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ret := make(map[string]*hcl.File, len(p.files))
+	for fn, f := range p.files {
+		ret[fn] = f
+	}
+	return ret
+}
+
+// ParseFileFS reads the given filename from fsys and parses it as JSON,
+// in the same manner as ParseFile.
+//
+// This is a convenience wrapper around creating a throwaway Parser and
+// calling its ParseFileFS method. Callers that want to retain the parsed
+// file for later diagnostic rendering should construct a Parser with
+// NewParser and call its methods directly instead.
+func ParseFileFS(fsys fs.FS, filename string) (*hcl.File, hcl.Diagnostics) {
+	return NewParser().ParseFileFS(fsys, filename)
+}
+
+// ParseReader reads all of r and parses the result as JSON, in the same
+// manner as Parse.
+//
+// This is a convenience wrapper around creating a throwaway Parser and
+// calling its ParseReader method.
+func ParseReader(r io.Reader, filename string) (*hcl.File, hcl.Diagnostics) {
+	return NewParser().ParseReader(r, filename)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"testing/fstest"
+)
+
+func TestParseFileFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.json": &fstest.MapFile{
+			Data: []byte(`{"foo": "bar"}`),
+		},
+	}
+
+	t.Run("existing file", func(t *testing.T) {
+		file, diags := ParseFileFS(fsys, "config.json")
+		if diags.HasErrors() {
+			t.Fatalf("unexpected diagnostics: %s", diags)
+		}
+		if file == nil {
+			t.Fatal("got nil file")
+		}
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, diags := ParseFileFS(fsys, "does-not-exist.json")
+		if !diags.HasErrors() {
+			t.Fatal("expected an error diagnostic for a missing file")
+		}
+		if got, want := diags[0].Summary, "Failed to open file"; got != want {
+			t.Errorf("wrong diagnostic summary\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
+
+func TestParserFiles(t *testing.T) {
+	fsys := fstest.MapFS{
+		"config.json": &fstest.MapFile{
+			Data: []byte(`{"foo": "bar"}`),
+		},
+	}
+
+	p := NewParser()
+	if _, diags := p.ParseFileFS(fsys, "config.json"); diags.HasErrors() {
+		t.Fatalf("unexpected diagnostics: %s", diags)
+	}
+
+	files := p.Files()
+	if _, ok := files["config.json"]; !ok {
+		t.Fatal("parsed file not present in Files()")
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("simulated read error")
+}
+
+func TestParseReader(t *testing.T) {
+	t.Run("read error", func(t *testing.T) {
+		_, diags := ParseReader(errReader{}, "broken.json")
+		if !diags.HasErrors() {
+			t.Fatal("expected an error diagnostic for a reader that fails")
+		}
+		if got, want := diags[0].Summary, "Failed to read file"; got != want {
+			t.Errorf("wrong diagnostic summary\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+}
+
+func TestParseFile(t *testing.T) {
+	t.Run("missing file", func(t *testing.T) {
+		_, diags := ParseFile("does-not-exist.json")
+		if !diags.HasErrors() {
+			t.Fatal("expected an error diagnostic for a missing file")
+		}
+		if got, want := diags[0].Summary, "Failed to open file"; got != want {
+			t.Errorf("wrong diagnostic summary\ngot:  %s\nwant: %s", got, want)
+		}
+	})
+
+	t.Run("existing file", func(t *testing.T) {
+		dir := t.TempDir()
+		path := dir + string(os.PathSeparator) + "config.json"
+		if err := os.WriteFile(path, []byte(`{"foo": "bar"}`), 0o644); err != nil {
+			t.Fatalf("failed to write fixture: %s", err)
+		}
+
+		file, diags := ParseFile(path)
+		if diags.HasErrors() {
+			t.Fatalf("unexpected diagnostics: %s", diags)
+		}
+		if file == nil {
+			t.Fatal("got nil file")
+		}
+	})
+}
@@ -0,0 +1,178 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import "github.com/hashicorp/hcl/v2"
+
+// Comment represents a single "//" line comment or "/* */" block comment
+// found while scanning a JSONC-flavored document with
+// Options.AllowComments set.
+type Comment struct {
+	// Text is the comment's literal source text, including its
+	// delimiters ("//", "/*", "*/").
+	Text string
+
+	// Range is the comment's location in the original source.
+	Range hcl.Range
+}
+
+// normalizeJSONC scans src for the JSONC constructs enabled by opts --
+// comments and trailing commas -- and returns a copy of src with those
+// constructs overwritten with whitespace, so that the strict JSON
+// scanner can consume the result unmodified, along with every comment it
+// found, with its original source range, so that a caller does not lose
+// them.
+//
+// Overwriting in place with spaces (and, for block comments, preserved
+// newlines) keeps every remaining byte at its original offset, so
+// diagnostics produced from the normalized source line up with the
+// original file.
+//
+// filename and start are used only to compute accurate Range values on
+// the returned comments; they do not affect the normalized bytes.
+func normalizeJSONC(src []byte, filename string, start hcl.Pos, opts Options) ([]byte, []Comment) {
+	if !opts.AllowComments && !opts.AllowTrailingCommas {
+		return src, nil
+	}
+
+	out := make([]byte, len(src))
+	copy(out, src)
+
+	var comments []Comment
+	pos := start
+	var inString, escaped bool
+
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+
+		switch {
+		case inString:
+			switch {
+			case escaped:
+				escaped = false
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+			}
+			advanceJSONCPos(&pos, c)
+
+		case c == '"':
+			inString = true
+			advanceJSONCPos(&pos, c)
+
+		case opts.AllowComments && c == '/' && i+1 < len(out) && out[i+1] == '/':
+			commentStart := pos
+			end := blankLineComment(out, i)
+			for j := i; j <= end; j++ {
+				advanceJSONCPos(&pos, src[j])
+			}
+			comments = append(comments, Comment{
+				Text:  string(src[i : end+1]),
+				Range: hcl.Range{Filename: filename, Start: commentStart, End: pos},
+			})
+			i = end
+
+		case opts.AllowComments && c == '/' && i+1 < len(out) && out[i+1] == '*':
+			commentStart := pos
+			end := blankBlockComment(out, i)
+			for j := i; j <= end; j++ {
+				advanceJSONCPos(&pos, src[j])
+			}
+			comments = append(comments, Comment{
+				Text:  string(src[i : end+1]),
+				Range: hcl.Range{Filename: filename, Start: commentStart, End: pos},
+			})
+			i = end
+
+		case opts.AllowTrailingCommas && c == ',' && isTrailingComma(out, i, opts):
+			out[i] = ' '
+			advanceJSONCPos(&pos, c)
+
+		default:
+			advanceJSONCPos(&pos, c)
+		}
+	}
+
+	return out, comments
+}
+
+// advanceJSONCPos moves pos past the single source byte b.
+//
+// Column is counted in runes rather than bytes, so continuation bytes of
+// a multi-byte UTF-8 sequence (which always have the high bit pattern
+// 10xxxxxx) do not themselves advance the column.
+func advanceJSONCPos(pos *hcl.Pos, b byte) {
+	pos.Byte++
+	if b == '\n' {
+		pos.Line++
+		pos.Column = 1
+		return
+	}
+	if b&0xC0 != 0x80 {
+		pos.Column++
+	}
+}
+
+// blankLineComment overwrites the "//" comment starting at out[i] with
+// spaces, stopping before its terminating newline (if any), and returns
+// the index of the last byte it overwrote.
+func blankLineComment(out []byte, i int) int {
+	for i < len(out) && out[i] != '\n' {
+		out[i] = ' '
+		i++
+	}
+	return i - 1
+}
+
+// blankBlockComment overwrites the "/* */" comment starting at out[i]
+// with spaces -- preserving any newlines within it, so that line numbers
+// later in the file are unaffected -- and returns the index of the last
+// byte it overwrote.
+func blankBlockComment(out []byte, i int) int {
+	end := i + 2
+	for end+1 < len(out) && !(out[end] == '*' && out[end+1] == '/') {
+		end++
+	}
+	if end+1 < len(out) {
+		end += 2 // include the closing "*/"
+	} else {
+		end = len(out) // unterminated: blank to the end of input
+	}
+	for j := i; j < end; j++ {
+		if out[j] != '\n' {
+			out[j] = ' '
+		}
+	}
+	return end - 1
+}
+
+// isTrailingComma reports whether the comma at out[i] is followed only
+// by whitespace, and comments if opts.AllowComments is set, before a
+// closing "}" or "]" -- i.e. whether it is safe to remove.
+func isTrailingComma(out []byte, i int, opts Options) bool {
+	for j := i + 1; j < len(out); j++ {
+		switch {
+		case out[j] == ' ' || out[j] == '\t' || out[j] == '\r' || out[j] == '\n':
+			continue
+		case opts.AllowComments && out[j] == '/' && j+1 < len(out) && out[j+1] == '/':
+			for j < len(out) && out[j] != '\n' {
+				j++
+			}
+			continue
+		case opts.AllowComments && out[j] == '/' && j+1 < len(out) && out[j+1] == '*':
+			k := j + 2
+			for k+1 < len(out) && !(out[k] == '*' && out[k+1] == '/') {
+				k++
+			}
+			j = k + 1
+			continue
+		case out[j] == '}' || out[j] == ']':
+			return true
+		default:
+			return false
+		}
+	}
+	return false
+}
@@ -0,0 +1,138 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestNormalizeJSONC(t *testing.T) {
+	defaultStart := hcl.Pos{Byte: 0, Line: 1, Column: 1}
+
+	tests := []struct {
+		name        string
+		src         string
+		opts        Options
+		want        string
+		wantComment []string
+	}{
+		{
+			name: "options off is a no-op, even with comment-like content",
+			src:  `{"a": "// not a comment"}`,
+			opts: Options{},
+			want: `{"a": "// not a comment"}`,
+		},
+		{
+			name: "line comment",
+			src:  "{\n  // a comment\n  \"a\": 1\n}",
+			opts: Options{AllowComments: true},
+			want: "{\n  " + spaces(len("// a comment")) + "\n  \"a\": 1\n}",
+			wantComment: []string{
+				"// a comment",
+			},
+		},
+		{
+			name: "block comment preserves embedded newlines",
+			src:  "{\n  /* a\n     comment */\n  \"a\": 1\n}",
+			opts: Options{AllowComments: true},
+			want: "{\n  " + spaces(len("/* a")) + "\n" + spaces(len("     comment */")) + "\n  \"a\": 1\n}",
+			wantComment: []string{
+				"/* a\n     comment */",
+			},
+		},
+		{
+			name: "unterminated block comment is blanked to the end of input",
+			src:  `{"a": 1} /* oops`,
+			opts: Options{AllowComments: true},
+			want: `{"a": 1} ` + spaces(len("/* oops")),
+			wantComment: []string{
+				"/* oops",
+			},
+		},
+		{
+			name: "comment-like text inside a string is left untouched",
+			src:  `{"url": "http://example.com/*not-a-comment*/"}`,
+			opts: Options{AllowComments: true},
+			want: `{"url": "http://example.com/*not-a-comment*/"}`,
+		},
+		{
+			name: "comma inside a string is left untouched",
+			src:  `{"a": "one, two"}`,
+			opts: Options{AllowTrailingCommas: true},
+			want: `{"a": "one, two"}`,
+		},
+		{
+			name: "trailing comma before a closing brace",
+			src:  `{"a": 1,}`,
+			opts: Options{AllowTrailingCommas: true},
+			want: `{"a": 1 }`,
+		},
+		{
+			name: "trailing comma before a closing bracket",
+			src:  `[1, 2,]`,
+			opts: Options{AllowTrailingCommas: true},
+			want: `[1, 2 ]`,
+		},
+		{
+			name: "interior comma is not treated as trailing",
+			src:  `[1, 2]`,
+			opts: Options{AllowTrailingCommas: true},
+			want: `[1, 2]`,
+		},
+		{
+			name: "trailing comma followed by a comment before the closing bracket",
+			src:  "[1, 2, // trailing\n]",
+			opts: Options{AllowComments: true, AllowTrailingCommas: true},
+			want: "[1, 2  " + spaces(len("// trailing")) + "\n]",
+			wantComment: []string{
+				"// trailing",
+			},
+		},
+		{
+			name: "CRLF line comment runs up to the LF, including the CR",
+			src:  "{\n  // a comment\r\n  \"a\": 1\n}",
+			opts: Options{AllowComments: true},
+			want: "{\n  " + spaces(len("// a comment\r")) + "\n  \"a\": 1\n}",
+			wantComment: []string{
+				"// a comment\r",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, comments := normalizeJSONC([]byte(test.src), "test.json", defaultStart, test.opts)
+
+			if got := string(got); got != test.want {
+				t.Errorf("wrong output\ngot:  %q\nwant: %q", got, test.want)
+			}
+
+			if test.opts == (Options{}) {
+				if !bytes.Equal(got, []byte(test.src)) {
+					t.Errorf("options-off output is not byte-for-byte identical to the input")
+				}
+			}
+
+			var gotComments []string
+			for _, c := range comments {
+				gotComments = append(gotComments, c.Text)
+			}
+			if len(gotComments) != len(test.wantComment) {
+				t.Fatalf("wrong number of comments\ngot:  %#v\nwant: %#v", gotComments, test.wantComment)
+			}
+			for i := range gotComments {
+				if gotComments[i] != test.wantComment[i] {
+					t.Errorf("wrong comment %d\ngot:  %q\nwant: %q", i, gotComments[i], test.wantComment[i])
+				}
+			}
+		})
+	}
+}
+
+func spaces(n int) string {
+	return string(bytes.Repeat([]byte{' '}, n))
+}
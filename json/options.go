@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// Options controls optional relaxations to the strict RFC 8259 grammar
+// that Parse and ParseFile otherwise implement.
+type Options struct {
+	// AllowComments allows "//" line comments and "/* */" block comments
+	// to appear anywhere whitespace is otherwise permitted.
+	AllowComments bool
+
+	// AllowTrailingCommas allows one extra comma after the final element
+	// of a JSON object or array, immediately before its closing "}" or
+	// "]".
+	AllowTrailingCommas bool
+}
+
+// FileWithComments pairs a parsed JSONC file with the comments found in
+// its source, in source order, so that a future formatter or printer can
+// recover and round-trip them alongside the structural result. Comments
+// is always empty when Options.AllowComments is false.
+//
+// Comments are deliberately not attached to individual nodes of the
+// parsed body, unlike hclsyntax's token-based comment tracking: the json
+// package's node types have no room reserved for that association, and
+// retrofitting it is out of scope for this change. Instead, each Comment
+// carries its own hcl.Range in the source, which a caller can use to
+// reassociate it with the nearest node -- for example, the node whose
+// range starts immediately after the comment's range ends -- by walking
+// the body returned in File. This ranged-list shape is the intended
+// contract for ParseWithOptions, not an interim step toward per-node
+// attachment.
+type FileWithComments struct {
+	*hcl.File
+
+	Comments []Comment
+}
+
+// ParseWithOptions is like Parse except that it accepts Options
+// describing a relaxed, JSONC-like dialect of JSON to parse, rather than
+// always requiring strict RFC 8259 JSON.
+//
+// When opts is the zero value, ParseWithOptions behaves identically to
+// Parse, byte-for-byte, so that existing strict-JSON callers such as
+// Terraform's *.tf.json loader see no change in behavior.
+func ParseWithOptions(src []byte, filename string, opts Options) (*FileWithComments, hcl.Diagnostics) {
+	return ParseWithOptionsAndStartPos(src, filename, hcl.Pos{Byte: 0, Line: 1, Column: 1}, opts)
+}
+
+// ParseWithOptionsAndStartPos is like ParseWithOptions but, like
+// ParseWithStartPos, allows the caller to pass a non-default start
+// position for the given source.
+func ParseWithOptionsAndStartPos(src []byte, filename string, start hcl.Pos, opts Options) (*FileWithComments, hcl.Diagnostics) {
+	// The scanner and expression parser in this package only understand
+	// strict JSON, so for the relaxed dialect we normalize the relaxed
+	// constructs out of the source before handing it to them, overwriting
+	// comments and trailing commas with spaces so that byte offsets, line
+	// numbers and columns are unaffected. The comments themselves are not
+	// discarded: normalizeJSONC also returns them, with their original
+	// source ranges, so callers get them back on FileWithComments.
+	normalized, comments := normalizeJSONC(src, filename, start, opts)
+	file, diags := ParseWithStartPos(normalized, filename, start)
+	return &FileWithComments{File: file, Comments: comments}, diags
+}
+
+// ParseFileWithOptions is a convenience wrapper around ParseWithOptions
+// that first attempts to load data from the given filename, in the same
+// manner as ParseFile.
+func ParseFileWithOptions(filename string, opts Options) (rf *FileWithComments, diags hcl.Diagnostics) {
+	f, err := os.Open(filename)
+	if err != nil {
+		diags = append(diags, &hcl.Diagnostic{
+			Severity: hcl.DiagError,
+			Summary:  "Failed to open file",
+			Detail:   fmt.Sprintf("The file %q could not be opened.", filename),
+		})
+		return
+	}
+	defer func() {
+		err := f.Close()
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagWarning,
+				Summary:  "Failed to close file",
+				Detail:   fmt.Sprintf("The file %q was opened, but an error occured while closing it.", filename),
+			})
+		}
+	}()
+
+	src, err := io.ReadAll(f)
+	if err != nil {
+		return nil, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read file",
+				Detail:   fmt.Sprintf("The file %q was opened, but an error occured while reading it.", filename),
+			},
+		}
+	}
+
+	return ParseWithOptions(src, filename, opts)
+}
+
+// ParseExpressionWithOptions is like ParseExpression except that it
+// accepts Options in the same way as ParseWithOptions, so that a
+// standalone JSONC expression can be parsed outside of a full document.
+func ParseExpressionWithOptions(src []byte, filename string, opts Options) (hcl.Expression, hcl.Diagnostics) {
+	return ParseExpressionWithOptionsAndStartPos(src, filename, hcl.Pos{Byte: 0, Line: 1, Column: 1}, opts)
+}
+
+// ParseExpressionWithOptionsAndStartPos is like ParseExpressionWithOptions
+// but, like ParseExpressionWithStartPos, allows the caller to pass a
+// non-default start position for the given source.
+func ParseExpressionWithOptionsAndStartPos(src []byte, filename string, start hcl.Pos, opts Options) (hcl.Expression, hcl.Diagnostics) {
+	normalized, _ := normalizeJSONC(src, filename, start, opts)
+	return ParseExpressionWithStartPos(normalized, filename, start)
+}
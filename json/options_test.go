@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package json
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestParseWithOptions_optionsOffMatchesParse(t *testing.T) {
+	const src = `{"a": 1, "b": [true, false, null], "c": "hello"}`
+
+	strictFile, strictDiags := Parse([]byte(src), "test.json")
+	gotFile, gotDiags := ParseWithOptions([]byte(src), "test.json", Options{})
+
+	if gotDiags.HasErrors() || strictDiags.HasErrors() {
+		t.Fatalf("unexpected diagnostics: strict=%s, options=%s", strictDiags, gotDiags)
+	}
+	if len(gotDiags) != len(strictDiags) {
+		t.Fatalf("diagnostic count differs: strict=%d, options=%d", len(strictDiags), len(gotDiags))
+	}
+	if len(gotFile.Comments) != 0 {
+		t.Errorf("expected no comments with zero-value Options, got %d", len(gotFile.Comments))
+	}
+	if string(gotFile.Bytes) != string(strictFile.Bytes) {
+		t.Errorf("options-off source bytes differ from strict Parse's")
+	}
+}
+
+func TestParseWithOptions_commentsAndTrailingCommas(t *testing.T) {
+	const src = `{
+  // the answer
+  "a": 1,
+  "b": [true, false,], /* inline */
+}`
+
+	file, diags := ParseWithOptions([]byte(src), "test.json", Options{
+		AllowComments:       true,
+		AllowTrailingCommas: true,
+	})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected diagnostics: %s", diags)
+	}
+
+	content, _, contentDiags := file.Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{
+			{Name: "a"},
+			{Name: "b"},
+		},
+	})
+	if contentDiags.HasErrors() {
+		t.Fatalf("unexpected diagnostics extracting content: %s", contentDiags)
+	}
+
+	aAttr, ok := content.Attributes["a"]
+	if !ok {
+		t.Fatal("missing attribute \"a\"")
+	}
+	aVal, valDiags := aAttr.Expr.Value(nil)
+	if valDiags.HasErrors() {
+		t.Fatalf("unexpected diagnostics evaluating \"a\": %s", valDiags)
+	}
+	if !aVal.RawEquals(cty.NumberIntVal(1)) {
+		t.Errorf("wrong value for \"a\": got %#v", aVal)
+	}
+
+	wantComments := []string{"// the answer", "/* inline */"}
+	if len(file.Comments) != len(wantComments) {
+		t.Fatalf("wrong number of comments: got %d, want %d (%#v)", len(file.Comments), len(wantComments), file.Comments)
+	}
+	for i, want := range wantComments {
+		if got := file.Comments[i].Text; got != want {
+			t.Errorf("wrong comment %d: got %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestParseFileWithOptions(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + string(os.PathSeparator) + "config.jsonc"
+	const src = `{"a": 1,} // trailing`
+	if err := os.WriteFile(path, []byte(src), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %s", err)
+	}
+
+	file, diags := ParseFileWithOptions(path, Options{
+		AllowComments:       true,
+		AllowTrailingCommas: true,
+	})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected diagnostics: %s", diags)
+	}
+	if len(file.Comments) != 1 || file.Comments[0].Text != "// trailing" {
+		t.Errorf("wrong comments: %#v", file.Comments)
+	}
+}
+
+func TestParseExpressionWithOptions(t *testing.T) {
+	const src = `[1, 2, /* two */ 3,]`
+
+	expr, diags := ParseExpressionWithOptions([]byte(src), "test.json", Options{
+		AllowComments:       true,
+		AllowTrailingCommas: true,
+	})
+	if diags.HasErrors() {
+		t.Fatalf("unexpected diagnostics: %s", diags)
+	}
+
+	val, valDiags := expr.Value(nil)
+	if valDiags.HasErrors() {
+		t.Fatalf("unexpected diagnostics evaluating expression: %s", valDiags)
+	}
+	if got, want := val.LengthInt(), 3; got != want {
+		t.Errorf("wrong length: got %d, want %d", got, want)
+	}
+}
@@ -5,7 +5,6 @@ package json
 
 import (
 	"fmt"
-	"io"
 	"os"
 
 	"github.com/hashicorp/hcl/v2"
@@ -92,6 +91,11 @@ func ParseExpressionWithStartPos(src []byte, filename string, start hcl.Pos) (hc
 // data from the given filename, passing the result to Parse if successful.
 //
 // If the file cannot be read, an error diagnostic with nil context is returned.
+//
+// ParseFile always reads from the real OS filesystem, even if the caller
+// has otherwise arranged for os.Open to be intercepted. To parse JSON from
+// an arbitrary io/fs.FS, such as an embed.FS or a virtual filesystem, use
+// ParseFileFS instead.
 func ParseFile(filename string) (rf *hcl.File, diags hcl.Diagnostics) {
 	f, err := os.Open(filename)
 	if err != nil {
@@ -113,16 +117,5 @@ func ParseFile(filename string) (rf *hcl.File, diags hcl.Diagnostics) {
 		}
 	}()
 
-	src, err := io.ReadAll(f)
-	if err != nil {
-		return nil, hcl.Diagnostics{
-			{
-				Severity: hcl.DiagError,
-				Summary:  "Failed to read file",
-				Detail:   fmt.Sprintf("The file %q was opened, but an error occured while reading it.", filename),
-			},
-		}
-	}
-
-	return Parse(src, filename)
+	return NewParser().ParseReader(f, filename)
 }